@@ -3,197 +3,206 @@ package test
 import (
 	"encoding/json"
 	"errors"
-	"fmt"
 	"math"
 	"reflect"
-	"strings"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/shoenig/test/internal/constraints"
+	"github.com/shoenig/test/internal/core"
 )
 
-func fail(t T, msg string, args ...any) {
-	s := fmt.Sprintf(msg, args...)
-	t.Logf(strings.TrimSpace(s) + "\n")
-	t.Fail()
+// Option customizes how a single assertion call reports failure. See
+// Sprintf, Context, and WithReporter.
+type Option = core.Option
+
+// Sprintf sets a formatted message logged ahead of an assertion's own
+// failure message, so table-driven tests can identify which case failed,
+// e.g. test.Eq(t, got, want, test.Sprintf("case %d", i)).
+func Sprintf(format string, args ...any) Option {
+	return core.Sprintf(format, args...)
+}
+
+// Context attaches a key/value pair logged alongside an assertion failure.
+func Context(key, value string) Option {
+	return core.Context(key, value)
+}
+
+// WithReporter overrides the Reporter used by a single assertion call,
+// without changing the package-wide default set via SetReporter.
+func WithReporter(r Reporter) Option {
+	return core.WithReporter(r)
+}
+
+// fail logs msg and marks t as failed, allowing the rest of the test to run.
+func fail(t T, opts []Option, msg string, args ...any) {
+	core.Fail(t, opts, msg, args...)
 }
 
 // diff creates a diff of a and b using cmp.Diff if possible, falling back to printing
 // the Go string values of both types (e.g. contains unexported fields).
-func diff[A, B any](a A, b B) (s string) {
-	defer func() {
-		if r := recover(); r != nil {
-			s = fmt.Sprintf("difference!\na: %#v\nb: %#v\n", a, b)
-		}
-	}()
-	s = "difference!\n" + cmp.Diff(a, b)
-	return
+// A Reporter set via SetReporter or passed via WithReporter replaces that default.
+func diff[A, B any](a A, b B, opts ...Option) string {
+	return core.Diff(a, b, opts)
 }
 
 // equal compares a and b using cmp.Equal if possible, falling back to reflect.DeepEqual
 // (e.g. contains unexported fields).
-func equal[A, B any](a A, b B) (result bool) {
-	defer func() {
-		if r := recover(); r != nil {
-			result = reflect.DeepEqual(a, b)
-		}
-	}()
-	result = cmp.Equal(a, b)
-	return
+func equal[A, B any](a A, b B) bool {
+	return core.Equal(a, b)
 }
 
 // Nil asserts a is nil.
-func Nil(t T, a any) {
+func Nil(t T, a any, opts ...Option) {
 	t.Helper()
 
 	if a != nil {
-		fail(t, ";; expected to be nil; is not nil")
+		fail(t, opts, ";; expected to be nil; is not nil")
 	}
 }
 
 // NotNil asserts a is not nil.
-func NotNil(t T, a any) {
+func NotNil(t T, a any, opts ...Option) {
 	t.Helper()
 
 	if a == nil {
-		fail(t, ";; expected to not be nil; is nil")
+		fail(t, opts, ";; expected to not be nil; is nil")
 	}
 }
 
 // True asserts that condition is true.
-func True(t T, condition bool) {
+func True(t T, condition bool, opts ...Option) {
 	t.Helper()
 
 	if !condition {
-		fail(t, ";; expected condition to be true; is false")
+		fail(t, opts, ";; expected condition to be true; is false")
 	}
 }
 
 // False asserts condition is false.
-func False(t T, condition bool) {
+func False(t T, condition bool, opts ...Option) {
 	t.Helper()
 
 	if condition {
-		fail(t, ";; expected condition to be false; is true")
+		fail(t, opts, ";; expected condition to be false; is true")
 	}
 }
 
 // Error asserts err is a non-nil error.
-func Error(t T, err error) {
+func Error(t T, err error, opts ...Option) {
 	t.Helper()
 
 	if err == nil {
-		fail(t, ";; expected non-nil error; is nil")
+		fail(t, opts, ";; expected non-nil error; is nil")
 	}
 }
 
-func EqError(t T, err error, msg string) {
+func EqError(t T, err error, msg string, opts ...Option) {
 	t.Helper()
 
 	s := err.Error()
 	if s != msg {
 		t.Logf("msg: %q", msg)
 		t.Logf("err: %q", s)
-		fail(t, ";; expected matching error strings")
+		fail(t, opts, ";; expected matching error strings")
 	}
 }
 
 // ErrorIs asserts err
-func ErrorIs(t T, err error, target error) {
+func ErrorIs(t T, err error, target error, opts ...Option) {
 	t.Helper()
 
 	if !errors.Is(err, target) {
 		t.Logf("error: %v", err)
 		t.Logf("target: %v", target)
-		fail(t, ";; expected errors.Is match")
+		fail(t, opts, ";; expected errors.Is match")
 	}
 }
 
 // NoError asserts err is a nil error.
-func NoError(t T, err error) {
+func NoError(t T, err error, opts ...Option) {
 	t.Helper()
 
 	if err != nil {
 		t.Logf("error: %v", err)
-		fail(t, ";; expected nil error")
+		fail(t, opts, ";; expected nil error")
 	}
 }
 
 // Eq asserts a and b are equal using cmp.Equal.
-func Eq[A any](t T, a, b A) {
+func Eq[A any](t T, a, b A, opts ...Option) {
 	t.Helper()
 
 	if !equal(a, b) {
-		t.Logf(diff(a, b))
-		fail(t, ";; expected equality via cmp.Equal function")
+		t.Logf(diff(a, b, opts...))
+		fail(t, opts, ";; expected equality via cmp.Equal function")
 	}
 }
 
 // EqCmp asserts a == b.
-func EqCmp[C comparable](t T, a, b C) {
+func EqCmp[C comparable](t T, a, b C, opts ...Option) {
 	t.Helper()
 
 	if a != b {
-		t.Logf(diff(a, b))
-		fail(t, ";; expected equality via ==")
+		t.Logf(diff(a, b, opts...))
+		fail(t, opts, ";; expected equality via ==")
 	}
 }
 
 // EqFunc asserts a and b are equal using eq.
-func EqFunc[A any](t T, a, b A, eq func(a, b A) bool) {
+func EqFunc[A any](t T, a, b A, eq func(a, b A) bool, opts ...Option) {
 	t.Helper()
 
 	if !eq(a, b) {
-		t.Logf(diff(a, b))
-		fail(t, ";; expected equality via 'eq' function")
+		t.Logf(diff(a, b, opts...))
+		fail(t, opts, ";; expected equality via 'eq' function")
 	}
 }
 
 // NotEq asserts a != b.
-func NotEq[C comparable](t T, a, b C) {
+func NotEq[C comparable](t T, a, b C, opts ...Option) {
 	t.Helper()
 
 	if a == b {
-		fail(t, ";; expected inequality via !=")
+		fail(t, opts, ";; expected inequality via !=")
 	}
 }
 
 // NotEqFunc asserts a and b are not equal using eq.
-func NotEqFunc[A any](t T, a, b A, eq func(a, b A) bool) {
+func NotEqFunc[A any](t T, a, b A, eq func(a, b A) bool, opts ...Option) {
 	t.Helper()
 
 	if eq(a, b) {
-		fail(t, ";; expected inequality via 'eq' function")
+		fail(t, opts, ";; expected inequality via 'eq' function")
 	}
 }
 
 // EqJSON asserts a and b are equivalent JSON.
-func EqJSON(t T, a, b string) {
+func EqJSON(t T, a, b string, opts ...Option) {
 	t.Helper()
 
 	var expA, expB any
 
 	if err := json.Unmarshal([]byte(a), &expA); err != nil {
-		fail(t, "failed to unmarshal first argument as json: %v", err)
+		fail(t, opts, "failed to unmarshal first argument as json: %v", err)
 		return
 	}
 
 	if err := json.Unmarshal([]byte(b), &expB); err != nil {
-		fail(t, "failed to unmarshal second argument as json: %v", err)
+		fail(t, opts, "failed to unmarshal second argument as json: %v", err)
 		return
 	}
 
 	if !reflect.DeepEqual(expA, expB) {
 		jsonA, _ := json.Marshal(expA)
 		jsonB, _ := json.Marshal(expB)
-		t.Logf(diff(string(jsonA), string(jsonB)))
-		fail(t, ";; expected equality via json marshalling")
+		t.Logf(diff(string(jsonA), string(jsonB), opts...))
+		fail(t, opts, ";; expected equality via json marshalling")
 		return
 	}
 }
 
 // EqSliceFunc asserts elements of a and b are the same using eq.
-func EqSliceFunc[A any](t T, a, b []A, eq func(a, b A) bool) {
+func EqSliceFunc[A any](t T, a, b []A, eq func(a, b A) bool, opts ...Option) {
 	t.Helper()
 
 	lenA, lenB := len(a), len(b)
@@ -201,8 +210,8 @@ func EqSliceFunc[A any](t T, a, b []A, eq func(a, b A) bool) {
 	if lenA != lenB {
 		t.Logf("len(slice a): %d\n", lenA)
 		t.Logf("len(slice b): %d\n", lenB)
-		t.Logf(diff(a, b))
-		fail(t, ";; expected slices of same length")
+		t.Logf(diff(a, b, opts...))
+		fail(t, opts, ";; expected slices of same length")
 		return
 	}
 
@@ -215,34 +224,34 @@ func EqSliceFunc[A any](t T, a, b []A, eq func(a, b A) bool) {
 	}
 
 	if miss {
-		t.Logf(diff(a, b))
-		fail(t, ";; expected slice equality via 'eq' function")
+		t.Logf(diff(a, b, opts...))
+		fail(t, opts, ";; expected slice equality via 'eq' function")
 		return
 	}
 }
 
 // Equals asserts a.Equals(b).
-func Equals[E EqualsFunc[E]](t T, a, b E) {
+func Equals[E EqualsFunc[E]](t T, a, b E, opts ...Option) {
 	t.Helper()
 
 	if !a.Equals(b) {
-		t.Logf(diff(a, b))
-		fail(t, ";; expected equality via .Equals method")
+		t.Logf(diff(a, b, opts...))
+		fail(t, opts, ";; expected equality via .Equals method")
 	}
 }
 
 // NotEquals asserts !a.Equals(b).
-func NotEquals[E EqualsFunc[E]](t T, a, b E) {
+func NotEquals[E EqualsFunc[E]](t T, a, b E, opts ...Option) {
 	t.Helper()
 
 	if a.Equals(b) {
-		t.Logf(diff(a, b))
-		fail(t, ";; expected inequality via .Equals method")
+		t.Logf(diff(a, b, opts...))
+		fail(t, opts, ";; expected inequality via .Equals method")
 	}
 }
 
 // EqualsSlice asserts a[n].Equals(b[n]) for each element n in slices a and b.
-func EqualsSlice[E EqualsFunc[E]](t T, a, b []E) {
+func EqualsSlice[E EqualsFunc[E]](t T, a, b []E, opts ...Option) {
 	t.Helper()
 
 	lenA, lenB := len(a), len(b)
@@ -250,147 +259,308 @@ func EqualsSlice[E EqualsFunc[E]](t T, a, b []E) {
 	if lenA != lenB {
 		t.Logf("len(slice a): %d\n", lenA)
 		t.Logf("len(slice b): %d\n", lenB)
-		t.Logf(diff(a, b))
-		fail(t, ";; expected slices of same length")
+		t.Logf(diff(a, b, opts...))
+		fail(t, opts, ";; expected slices of same length")
 		return
 	}
 
 	for i := 0; i < lenA; i++ {
 		if !a[i].Equals(b[i]) {
-			t.Logf(diff(a[i], b[i]))
-			fail(t, ";; expected slice equality via .Equals method")
+			t.Logf(diff(a[i], b[i], opts...))
+			fail(t, opts, ";; expected slice equality via .Equals method")
 			return
 		}
 	}
 }
 
 // Lesser asserts a.Less(b).
-func Lesser[L LessFunc[L]](t T, a, b L) {
+func Lesser[L LessFunc[L]](t T, a, b L, opts ...Option) {
 	t.Helper()
 
 	if !a.Less(b) {
-		t.Logf(diff(a, b))
-		fail(t, ";; expected to be less via .Less method")
+		t.Logf(diff(a, b, opts...))
+		fail(t, opts, ";; expected to be less via .Less method")
 	}
 }
 
 // EmptySlice asserts slice is empty.
-func EmptySlice[A any](t T, slice []A) {
+func EmptySlice[A any](t T, slice []A, opts ...Option) {
 	t.Helper()
 
 	if len(slice) != 0 {
 		t.Logf("len(slice): %d\n", len(slice))
-		fail(t, ";; expected slice to be empty")
+		fail(t, opts, ";; expected slice to be empty")
 	}
 }
 
 // LenSlice asserts slice is of length n.
-func LenSlice[A any](t T, n int, slice []A) {
+func LenSlice[A any](t T, n int, slice []A, opts ...Option) {
 	t.Helper()
 
 	if l := len(slice); l != n {
 		t.Logf("len(slice): %d, expected: %d\n", l, n)
-		fail(t, ";; expected slice to be different length")
+		fail(t, opts, ";; expected slice to be different length")
 	}
 }
 
 func contains[C comparable](slice []C, item C) bool {
-	found := false
-	for i := 0; i < len(slice); i++ {
-		if slice[i] == item {
-			found = true
-			break
-		}
-	}
-	return found
+	return core.Contains(slice, item)
 }
 
 func containsFunc[A any](slice []A, item A, eq func(a, b A) bool) bool {
-	found := false
-	for i := 0; i < len(slice); i++ {
-		if eq(slice[i], item) {
-			found = true
-			break
-		}
-	}
-	return found
+	return core.ContainsFunc(slice, item, eq)
 }
 
 // Contains asserts item exists in slice using cmp.Equal function.
-func Contains[A any](t T, slice []A, item A) {
+func Contains[A any](t T, slice []A, item A, opts ...Option) {
 	t.Helper()
 
 	if !containsFunc(slice, item, func(a, b A) bool {
 		return equal(a, b)
 	}) {
 		t.Logf("slice is missing %#v\n", item)
-		fail(t, ";; expected slice to contain missing item via cmp.Equal function")
+		fail(t, opts, ";; expected slice to contain missing item via cmp.Equal function")
 	}
 }
 
 // ContainsCmp asserts item exists in slice using == operator.
-func ContainsCmp[C comparable](t T, slice []C, item C) {
+func ContainsCmp[C comparable](t T, slice []C, item C, opts ...Option) {
 	t.Helper()
 
 	if !contains(slice, item) {
 		t.Logf("slice is missing %#v\n", item)
-		fail(t, ";; expected slice to contain missing item via == operator")
+		fail(t, opts, ";; expected slice to contain missing item via == operator")
 	}
 }
 
 // ContainsFunc asserts item exists in slice, using eq to compare elements.
-func ContainsFunc[A any](t T, slice []A, item A, eq func(a, b A) bool) {
+func ContainsFunc[A any](t T, slice []A, item A, eq func(a, b A) bool, opts ...Option) {
 	t.Helper()
 
 	if !containsFunc(slice, item, eq) {
 		t.Logf("slice is missing %#v\n", item)
-		fail(t, ";; expected slice to contain missing item via 'eq' function")
+		fail(t, opts, ";; expected slice to contain missing item via 'eq' function")
 	}
 }
 
 // ContainsEquals asserts item exists in slice, using Equals to compare elements.
-func ContainsEquals[E EqualsFunc[E]](t T, slice []E, item E) {
+func ContainsEquals[E EqualsFunc[E]](t T, slice []E, item E, opts ...Option) {
 	t.Helper()
 
 	if !containsFunc(slice, item, E.Equals) {
 		t.Logf("slice is missing %#v\n", item)
-		fail(t, ";; expected slice to contain missing item via .Equals method")
+		fail(t, opts, ";; expected slice to contain missing item via .Equals method")
+	}
+}
+
+// SetEq asserts a and b contain the same multiset of elements regardless of
+// order, comparing elements via cmp.Equal.
+func SetEq[A any](t T, a, b []A, opts ...Option) {
+	t.Helper()
+
+	extra, missing := core.MultisetDiff(a, b, func(x, y A) bool { return equal(x, y) })
+	if len(extra) != 0 || len(missing) != 0 {
+		core.LogSetDiff(t, extra, missing)
+		fail(t, opts, ";; expected a and b to contain the same elements via cmp.Equal function")
+	}
+}
+
+// SetEqCmp asserts a and b contain the same multiset of elements regardless
+// of order, comparing elements via ==.
+func SetEqCmp[C comparable](t T, a, b []C, opts ...Option) {
+	t.Helper()
+
+	extra, missing := core.MultisetDiffCmp(a, b)
+	if len(extra) != 0 || len(missing) != 0 {
+		core.LogSetDiff(t, extra, missing)
+		fail(t, opts, ";; expected a and b to contain the same elements via ==")
+	}
+}
+
+// SubsetOf asserts every element of sub appears in super, comparing elements
+// via cmp.Equal.
+func SubsetOf[A any](t T, sub, super []A, opts ...Option) {
+	t.Helper()
+
+	var missing []A
+	for _, s := range sub {
+		if !containsFunc(super, s, func(x, y A) bool { return equal(x, y) }) {
+			missing = append(missing, s)
+		}
+	}
+	if len(missing) != 0 {
+		t.Logf("missing from super: %#v\n", missing)
+		fail(t, opts, ";; expected sub to be a subset of super")
+	}
+}
+
+// SubsetOfCmp is like SubsetOf but compares elements via ==.
+func SubsetOfCmp[C comparable](t T, sub, super []C, opts ...Option) {
+	t.Helper()
+
+	var missing []C
+	for _, s := range sub {
+		if !contains(super, s) {
+			missing = append(missing, s)
+		}
+	}
+	if len(missing) != 0 {
+		t.Logf("missing from super: %#v\n", missing)
+		fail(t, opts, ";; expected sub to be a subset of super")
+	}
+}
+
+// Disjoint asserts a and b have no elements in common, comparing elements via
+// cmp.Equal. The first collision found is reported.
+func Disjoint[A any](t T, a, b []A, opts ...Option) {
+	t.Helper()
+
+	for _, x := range a {
+		if containsFunc(b, x, func(p, q A) bool { return equal(p, q) }) {
+			t.Logf("common element: %#v\n", x)
+			fail(t, opts, ";; expected a and b to be disjoint")
+			return
+		}
+	}
+}
+
+// DisjointCmp is like Disjoint but compares elements via ==.
+func DisjointCmp[C comparable](t T, a, b []C, opts ...Option) {
+	t.Helper()
+
+	for _, x := range a {
+		if contains(b, x) {
+			t.Logf("common element: %#v\n", x)
+			fail(t, opts, ";; expected a and b to be disjoint")
+			return
+		}
+	}
+}
+
+// ContainsAll asserts every element of items is present in slice, comparing
+// elements via cmp.Equal.
+func ContainsAll[A any](t T, slice, items []A, opts ...Option) {
+	t.Helper()
+
+	var missing []A
+	for _, item := range items {
+		if !containsFunc(slice, item, func(x, y A) bool { return equal(x, y) }) {
+			missing = append(missing, item)
+		}
+	}
+	if len(missing) != 0 {
+		t.Logf("slice is missing %#v\n", missing)
+		fail(t, opts, ";; expected slice to contain all items")
+	}
+}
+
+// ContainsAllCmp is like ContainsAll but compares elements via ==.
+func ContainsAllCmp[C comparable](t T, slice, items []C, opts ...Option) {
+	t.Helper()
+
+	var missing []C
+	for _, item := range items {
+		if !contains(slice, item) {
+			missing = append(missing, item)
+		}
+	}
+	if len(missing) != 0 {
+		t.Logf("slice is missing %#v\n", missing)
+		fail(t, opts, ";; expected slice to contain all items")
+	}
+}
+
+// ContainsAny asserts at least one element of items is present in slice,
+// comparing elements via cmp.Equal.
+func ContainsAny[A any](t T, slice, items []A, opts ...Option) {
+	t.Helper()
+
+	for _, item := range items {
+		if containsFunc(slice, item, func(x, y A) bool { return equal(x, y) }) {
+			return
+		}
+	}
+	t.Logf("slice contains none of %#v\n", items)
+	fail(t, opts, ";; expected slice to contain at least one item")
+}
+
+// ContainsAnyCmp is like ContainsAny but compares elements via ==.
+func ContainsAnyCmp[C comparable](t T, slice, items []C, opts ...Option) {
+	t.Helper()
+
+	for _, item := range items {
+		if contains(slice, item) {
+			return
+		}
+	}
+	t.Logf("slice contains none of %#v\n", items)
+	fail(t, opts, ";; expected slice to contain at least one item")
+}
+
+// Unique asserts no element of slice appears more than once, comparing
+// elements via cmp.Equal.
+func Unique[A any](t T, slice []A, opts ...Option) {
+	t.Helper()
+
+	for i := 0; i < len(slice); i++ {
+		for j := i + 1; j < len(slice); j++ {
+			if equal(slice[i], slice[j]) {
+				t.Logf("duplicate element: %#v\n", slice[i])
+				fail(t, opts, ";; expected slice to contain unique elements")
+				return
+			}
+		}
+	}
+}
+
+// UniqueCmp is like Unique but compares elements via ==.
+func UniqueCmp[C comparable](t T, slice []C, opts ...Option) {
+	t.Helper()
+
+	seen := make(map[C]bool, len(slice))
+	for _, x := range slice {
+		if seen[x] {
+			t.Logf("duplicate element: %#v\n", x)
+			fail(t, opts, ";; expected slice to contain unique elements")
+			return
+		}
+		seen[x] = true
 	}
 }
 
 // Less asserts a < b.
-func Less[O constraints.Ordered](t T, a, b O) {
+func Less[O constraints.Ordered](t T, a, b O, opts ...Option) {
 	t.Helper()
 
 	if !(a < b) {
-		fail(t, ";; expected %v < %v", a, b)
+		fail(t, opts, ";; expected %v < %v", a, b)
 	}
 }
 
 // LessEq asserts a <= b.
-func LessEq[O constraints.Ordered](t T, a, b O) {
+func LessEq[O constraints.Ordered](t T, a, b O, opts ...Option) {
 	t.Helper()
 
 	if !(a <= b) {
-		fail(t, ";; expected %v <= %v", a, b)
+		fail(t, opts, ";; expected %v <= %v", a, b)
 	}
 }
 
 // Greater asserts a > b.
-func Greater[O constraints.Ordered](t T, a, b O) {
+func Greater[O constraints.Ordered](t T, a, b O, opts ...Option) {
 	t.Helper()
 
 	if !(a > b) {
-		fail(t, ";; expected %v > %v", a, b)
+		fail(t, opts, ";; expected %v > %v", a, b)
 	}
 }
 
 // GreaterEq asserts a >= b.
-func GreaterEq[O constraints.Ordered](t T, a, b O) {
+func GreaterEq[O constraints.Ordered](t T, a, b O, opts ...Option) {
 	t.Helper()
 
 	if !(a >= b) {
-		fail(t, ";; expected %v >= %v", a, b)
+		fail(t, opts, ";; expected %v >= %v", a, b)
 	}
 }
 
@@ -416,57 +586,57 @@ func Numeric[N Number](n N) bool {
 }
 
 // InDelta asserts a and b are within delta of each other.
-func InDelta[N Number](t T, a, b, delta N) {
+func InDelta[N Number](t T, a, b, delta N, opts ...Option) {
 	t.Helper()
 
 	var zero N
 
 	if !Numeric(delta) {
-		fail(t, ";; delta must be numeric; got %v", delta)
+		fail(t, opts, ";; delta must be numeric; got %v", delta)
 		return
 	}
 
 	if delta <= zero {
-		fail(t, ";; delta must be positive; got %v", delta)
+		fail(t, opts, ";; delta must be positive; got %v", delta)
 		return
 	}
 
 	if !Numeric(a) {
-		fail(t, ";; first argument must be numeric; got %v", a)
+		fail(t, opts, ";; first argument must be numeric; got %v", a)
 		return
 	}
 
 	if !Numeric(b) {
-		fail(t, ";; second argument must be numeric; got %v", b)
+		fail(t, opts, ";; second argument must be numeric; got %v", b)
 		return
 	}
 
 	difference := a - b
 	if difference < -delta || difference > delta {
-		fail(t, ";; %v and %v not within %v", a, b, delta)
+		fail(t, opts, ";; %v and %v not within %v", a, b, delta)
 		return
 	}
 }
 
 // InDeltaSlice asserts each element a[n] is within delta of b[n].
-func InDeltaSlice[N Number](t T, a, b []N, delta N) {
+func InDeltaSlice[N Number](t T, a, b []N, delta N, opts ...Option) {
 	t.Helper()
 
 	if len(a) != len(b) {
 		t.Logf("len(slice a): %d\n", len(a))
 		t.Logf("len(slice b): %d\n", len(b))
-		fail(t, ";; expected slices of same length")
+		fail(t, opts, ";; expected slices of same length")
 		return
 	}
 
 	for i := 0; i < len(a); i++ {
-		InDelta(t, a[i], b[i], delta)
+		InDelta(t, a[i], b[i], delta, opts...)
 	}
 }
 
 // MapEq asserts maps a and b contain the same key/value pairs, using
 // cmp.Equal function to compare values.
-func MapEq[M1, M2 Map[K, V], K comparable, V any](t T, a M1, b M2) {
+func MapEq[M1, M2 Map[K, V], K comparable, V any](t T, a M1, b M2, opts ...Option) {
 	t.Helper()
 
 	lenA, lenB := len(a), len(b)
@@ -474,21 +644,21 @@ func MapEq[M1, M2 Map[K, V], K comparable, V any](t T, a M1, b M2) {
 	if lenA != lenB {
 		t.Logf("len(map a): %d\n", lenA)
 		t.Logf("len(map b): %d\n", lenB)
-		fail(t, ";; expected maps of same length")
+		fail(t, opts, ";; expected maps of same length")
 		return
 	}
 
 	for key, valueA := range a {
 		valueB, exists := b[key]
 		if !exists {
-			t.Logf(diff(a, b))
-			fail(t, ";; expected maps of same keys")
+			t.Logf(diff(a, b, opts...))
+			fail(t, opts, ";; expected maps of same keys")
 			return
 		}
 
 		if !cmp.Equal(valueA, valueB) {
-			t.Logf(diff(a, b))
-			fail(t, ";; expected maps of same values via cmp.Diff function")
+			t.Logf(diff(a, b, opts...))
+			fail(t, opts, ";; expected maps of same values via cmp.Diff function")
 			return
 		}
 	}
@@ -496,7 +666,7 @@ func MapEq[M1, M2 Map[K, V], K comparable, V any](t T, a M1, b M2) {
 
 // MapEqFunc asserts maps a and b contain the same key/value pairs, using eq to
 // compare values.
-func MapEqFunc[M Map[K, V], K comparable, V any](t T, a, b M, eq func(V, V) bool) {
+func MapEqFunc[M Map[K, V], K comparable, V any](t T, a, b M, eq func(V, V) bool, opts ...Option) {
 	t.Helper()
 
 	lenA, lenB := len(a), len(b)
@@ -504,21 +674,21 @@ func MapEqFunc[M Map[K, V], K comparable, V any](t T, a, b M, eq func(V, V) bool
 	if lenA != lenB {
 		t.Logf("len(map a): %d\n", lenA)
 		t.Logf("len(map b): %d\n", lenB)
-		fail(t, ";; expected maps of same length")
+		fail(t, opts, ";; expected maps of same length")
 		return
 	}
 
 	for key, valueA := range a {
 		valueB, exists := b[key]
 		if !exists {
-			t.Logf(diff(a, b))
-			fail(t, ";; expected maps of same keys")
+			t.Logf(diff(a, b, opts...))
+			fail(t, opts, ";; expected maps of same keys")
 			return
 		}
 
 		if !eq(valueA, valueB) {
-			t.Logf(diff(a, b))
-			fail(t, ";; expected maps of same values via 'eq' function")
+			t.Logf(diff(a, b, opts...))
+			fail(t, opts, ";; expected maps of same values via 'eq' function")
 			return
 		}
 	}
@@ -526,7 +696,7 @@ func MapEqFunc[M Map[K, V], K comparable, V any](t T, a, b M, eq func(V, V) bool
 
 // MapEquals asserts maps a and b contain the same key/value pairs, using Equals
 // method to compare values
-func MapEquals[M MapEqualsFunc[K, V], K comparable, V EqualsFunc[V]](t T, a, b M) {
+func MapEquals[M MapEqualsFunc[K, V], K comparable, V EqualsFunc[V]](t T, a, b M, opts ...Option) {
 	t.Helper()
 
 	lenA, lenB := len(a), len(b)
@@ -534,42 +704,42 @@ func MapEquals[M MapEqualsFunc[K, V], K comparable, V EqualsFunc[V]](t T, a, b M
 	if lenA != lenB {
 		t.Logf("len(map a): %d\n", lenA)
 		t.Logf("len(map b): %d\n", lenB)
-		fail(t, ";; expected maps of same length")
+		fail(t, opts, ";; expected maps of same length")
 		return
 	}
 
 	for key, valueA := range a {
 		valueB, exists := b[key]
 		if !exists {
-			t.Logf(diff(a, b))
-			fail(t, ";; expected maps of same keys")
+			t.Logf(diff(a, b, opts...))
+			fail(t, opts, ";; expected maps of same keys")
 			return
 		}
 
 		if !(valueB).Equals(valueA) {
-			t.Logf(diff(a, b))
-			fail(t, ";; expected maps of same values via .Equals method")
+			t.Logf(diff(a, b, opts...))
+			fail(t, opts, ";; expected maps of same values via .Equals method")
 			return
 		}
 	}
 }
 
 // MapLen asserts map is of size n.
-func MapLen[M ~map[K]V, K comparable, V any](t T, n int, m M) {
+func MapLen[M ~map[K]V, K comparable, V any](t T, n int, m M, opts ...Option) {
 	t.Helper()
 
 	if l := len(m); l != n {
 		t.Logf("len(map): %d, expected: %d\n", l, n)
-		fail(t, ";; expected map to be different length")
+		fail(t, opts, ";; expected map to be different length")
 	}
 }
 
 // MapEmpty asserts map is empty.
-func MapEmpty[M ~map[K]V, K comparable, V any](t T, m M) {
+func MapEmpty[M ~map[K]V, K comparable, V any](t T, m M, opts ...Option) {
 	t.Helper()
 
 	if l := len(m); l > 0 {
 		t.Logf("len(map): %d\n", l)
-		fail(t, ";; expected map to be empty")
+		fail(t, opts, ";; expected map to be empty")
 	}
 }
@@ -0,0 +1,152 @@
+package test
+
+import (
+	"fmt"
+	"sync"
+)
+
+// cleanup is satisfied by testing.TB, letting NewCollector register its own
+// Report as a cleanup function automatically.
+type cleanup interface {
+	Cleanup(func())
+}
+
+// failure records the buffered Logf lines leading up to a single Fail call,
+// along with the prefix (if any) set via WithPrefix.
+type failure struct {
+	prefix string
+	logs   []string
+}
+
+// Collector is a T that buffers Logf/Fail calls instead of acting on them
+// immediately, so a table-driven test can see every failing case in a single
+// run instead of stopping at the first. Use NewCollector to obtain one, and
+// either let it report via t.Cleanup or call Report explicitly.
+type Collector struct {
+	t T
+
+	mu       sync.Mutex
+	pending  []string
+	failures []failure
+}
+
+// NewCollector wraps t in a Collector. If t implements Cleanup(func()) (as
+// testing.T and testing.B do), the Collector registers itself to Report
+// automatically at the end of the test.
+func NewCollector(t T) *Collector {
+	t.Helper()
+
+	c := &Collector{t: t}
+	if cl, ok := t.(cleanup); ok {
+		cl.Cleanup(c.Report)
+	}
+	return c
+}
+
+// Helper implements T. It is a no-op, since Collector's own failures are
+// reported later from Report, not attributed to the caller's line.
+func (c *Collector) Helper() {}
+
+// Logf implements T by buffering the formatted message against the failure
+// currently being assembled.
+func (c *Collector) Logf(format string, args ...any) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.pending = append(c.pending, fmt.Sprintf(format, args...))
+}
+
+// Fail implements T by closing out the currently buffered failure.
+func (c *Collector) Fail() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.failures = append(c.failures, failure{logs: c.pending})
+	c.pending = nil
+}
+
+// WithPrefix returns a T that behaves like c, tagging every failure it
+// records with prefix. Use it to label which table-driven case an assertion
+// belongs to, e.g. test.Eq(c.WithPrefix(fmt.Sprintf("case %d", i)), got, want).
+//
+// Each call returns a handle with its own Logf buffer, so cases that call
+// WithPrefix once each (the usage shown above) stay correctly attributed to
+// their own failure even if they interleave, e.g. via t.Parallel() inside a
+// t.Run table loop. A single WithPrefix handle is not itself safe to share
+// across goroutines, the same as any other T.
+func (c *Collector) WithPrefix(prefix string) T {
+	return &prefixedCollector{c: c, prefix: prefix}
+}
+
+// FailureCount returns the number of failures recorded so far.
+func (c *Collector) FailureCount() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return len(c.failures)
+}
+
+// Report logs every buffered failure, grouped by index and prefix, and calls
+// the wrapped T's Fail exactly once if any were recorded. It is safe to call
+// more than once; only unreported failures are emitted. NewCollector
+// registers Report as a Cleanup function automatically when possible, so
+// most callers never need to call it directly.
+func (c *Collector) Report() {
+	c.mu.Lock()
+	failures := c.failures
+	c.failures = nil
+	c.mu.Unlock()
+
+	if len(failures) == 0 {
+		return
+	}
+
+	c.t.Helper()
+	for i, f := range failures {
+		if f.prefix != "" {
+			c.t.Logf("failure %d (%s):\n", i, f.prefix)
+		} else {
+			c.t.Logf("failure %d:\n", i)
+		}
+		for _, line := range f.logs {
+			c.t.Logf("  %s", line)
+		}
+	}
+	c.t.Fail()
+}
+
+// prefixedCollector is the T returned by Collector.WithPrefix. It buffers
+// Logf lines in its own pending slice, independent of the parent Collector
+// and any other prefixedCollector, so that interleaved cases (e.g. under
+// t.Parallel()) can't mix each other's log lines into the wrong failure.
+type prefixedCollector struct {
+	c      *Collector
+	prefix string
+
+	mu      sync.Mutex
+	pending []string
+}
+
+func (p *prefixedCollector) Helper() {}
+
+func (p *prefixedCollector) Logf(format string, args ...any) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.pending = append(p.pending, fmt.Sprintf(format, args...))
+}
+
+func (p *prefixedCollector) Fail() {
+	p.mu.Lock()
+	logs := p.pending
+	p.pending = nil
+	p.mu.Unlock()
+
+	p.c.mu.Lock()
+	defer p.c.mu.Unlock()
+	p.c.failures = append(p.c.failures, failure{prefix: p.prefix, logs: logs})
+}
+
+// Soft wraps t in a Collector using its default (unprefixed) settings. It is
+// shorthand for NewCollector(t) for the common case of a single unindexed
+// batch of assertions.
+func Soft(t T) *Collector {
+	t.Helper()
+	return NewCollector(t)
+}
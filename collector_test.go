@@ -0,0 +1,142 @@
+package test
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+)
+
+type fakeTB struct {
+	mu      sync.Mutex
+	logs    []string
+	failed  bool
+	cleanup []func()
+}
+
+func (f *fakeTB) Helper() {}
+
+func (f *fakeTB) Logf(format string, args ...any) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.logs = append(f.logs, fmt.Sprintf(format, args...))
+}
+
+func (f *fakeTB) Fail() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.failed = true
+}
+
+func (f *fakeTB) Cleanup(fn func()) {
+	f.cleanup = append(f.cleanup, fn)
+}
+
+func (f *fakeTB) runCleanup() {
+	for _, fn := range f.cleanup {
+		fn()
+	}
+}
+
+func TestCollector_ReportGroupsFailuresInOrder(t *testing.T) {
+	ft := &fakeTB{}
+	c := NewCollector(ft)
+
+	c.Logf("case 0 detail")
+	c.Fail()
+
+	c.Logf("case 1 detail")
+	c.Fail()
+
+	if got := c.FailureCount(); got != 2 {
+		t.Fatalf("expected 2 buffered failures, got %d", got)
+	}
+
+	c.Report()
+
+	if !ft.failed {
+		t.Fatalf("expected Report to fail the wrapped T")
+	}
+	joined := strings.Join(ft.logs, "")
+	if !strings.Contains(joined, "failure 0:") || !strings.Contains(joined, "case 0 detail") {
+		t.Fatalf("expected failure 0 to be reported with its detail, got:\n%s", joined)
+	}
+	if !strings.Contains(joined, "failure 1:") || !strings.Contains(joined, "case 1 detail") {
+		t.Fatalf("expected failure 1 to be reported with its detail, got:\n%s", joined)
+	}
+}
+
+func TestCollector_ReportNoFailuresDoesNotFail(t *testing.T) {
+	ft := &fakeTB{}
+	c := NewCollector(ft)
+	c.Report()
+
+	if ft.failed {
+		t.Fatalf("expected Report to be a no-op when nothing failed")
+	}
+}
+
+func TestCollector_CleanupRegistration(t *testing.T) {
+	ft := &fakeTB{}
+	c := NewCollector(ft)
+	c.Logf("boom")
+	c.Fail()
+
+	ft.runCleanup()
+
+	if !ft.failed {
+		t.Fatalf("expected NewCollector to register Report as a Cleanup func")
+	}
+}
+
+// TestCollector_WithPrefixInterleaved is the regression case for two
+// WithPrefix handles whose Logf/Fail calls interleave (the normal shape of
+// t.Parallel() inside a t.Run table loop): each case's log lines must end up
+// attached to its own failure, not whichever case happens to call Fail
+// first.
+func TestCollector_WithPrefixInterleaved(t *testing.T) {
+	ft := &fakeTB{}
+	c := NewCollector(ft)
+
+	caseA := c.WithPrefix("case-A")
+	caseB := c.WithPrefix("case-B")
+
+	caseA.Logf("A's diff line")
+	caseB.Logf("B's diff line")
+	caseB.Fail()
+	caseA.Fail()
+
+	if got := c.FailureCount(); got != 2 {
+		t.Fatalf("expected 2 buffered failures, got %d", got)
+	}
+
+	c.Report()
+	joined := strings.Join(ft.logs, "")
+
+	aIdx := strings.Index(joined, "case-A")
+	bIdx := strings.Index(joined, "case-B")
+	if aIdx < 0 || bIdx < 0 {
+		t.Fatalf("expected both prefixes to appear, got:\n%s", joined)
+	}
+
+	// Whichever order the two failures were recorded in, case-A's own log
+	// line must not appear before case-A's header (i.e. inside case-B's
+	// block), and vice versa.
+	if strings.Contains(joined[:aIdx], "A's diff line") {
+		t.Fatalf("case-A's diff line leaked into an earlier failure block:\n%s", joined)
+	}
+	if strings.Contains(joined[:bIdx], "B's diff line") {
+		t.Fatalf("case-B's diff line leaked into an earlier failure block:\n%s", joined)
+	}
+}
+
+func TestSoft(t *testing.T) {
+	ft := &fakeTB{}
+	c := Soft(ft)
+	c.Logf("x")
+	c.Fail()
+
+	if c.FailureCount() != 1 {
+		t.Fatalf("expected Soft to behave like NewCollector")
+	}
+}
@@ -0,0 +1,168 @@
+// Package httpassert provides assertions for exercising an http.Handler
+// directly, without starting a real network listener. Each assertion builds
+// a request via httptest.NewRequest, records the response with
+// httptest.NewRecorder, and reports a failure via the same T interface used
+// by the test package.
+package httpassert
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+
+	"github.com/shoenig/test"
+	"github.com/shoenig/test/internal/core"
+)
+
+// T is the subset of testing.TB needed to run an HTTP assertion.
+type T interface {
+	Helper()
+	Logf(format string, args ...any)
+	Fail()
+}
+
+// RequestOption customizes the *http.Request built by an assertion before it
+// is served to the handler.
+type RequestOption func(*http.Request)
+
+// Header sets header to value on the request.
+func Header(header, value string) RequestOption {
+	return func(r *http.Request) {
+		r.Header.Set(header, value)
+	}
+}
+
+// Cookie attaches cookie to the request.
+func Cookie(cookie *http.Cookie) RequestOption {
+	return func(r *http.Request) {
+		r.AddCookie(cookie)
+	}
+}
+
+// Context replaces the request's context, e.g. with one carrying an auth
+// token or a deadline.
+func Context(ctx context.Context) RequestOption {
+	return func(r *http.Request) {
+		*r = *r.WithContext(ctx)
+	}
+}
+
+const bodyTruncateLen = 512
+
+func truncate(s string) string {
+	if len(s) <= bodyTruncateLen {
+		return s
+	}
+	return s[:bodyTruncateLen] + "... (truncated)"
+}
+
+func do(h http.Handler, method, url string, body io.Reader, opts ...RequestOption) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, url, body)
+	for _, opt := range opts {
+		opt(req)
+	}
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, req)
+	return rec
+}
+
+func fail(t T, rec *httptest.ResponseRecorder, method, url, msg string, args ...any) {
+	t.Logf("method: %s\n", method)
+	t.Logf("url: %s\n", url)
+	t.Logf("status: %d\n", rec.Code)
+	t.Logf("body: %s\n", truncate(rec.Body.String()))
+	core.Fail(t, nil, msg, args...)
+}
+
+// HTTPStatus asserts h responds to method/url with wantCode.
+func HTTPStatus(t T, h http.Handler, method, url string, body io.Reader, wantCode int, opts ...RequestOption) {
+	t.Helper()
+
+	rec := do(h, method, url, body, opts...)
+	if rec.Code != wantCode {
+		fail(t, rec, method, url, ";; expected status code %d; got %d", wantCode, rec.Code)
+	}
+}
+
+func statusClass(t T, h http.Handler, method, url string, body io.Reader, lo, hi int, class string, opts ...RequestOption) {
+	t.Helper()
+
+	rec := do(h, method, url, body, opts...)
+	if rec.Code < lo || rec.Code > hi {
+		fail(t, rec, method, url, ";; expected %s status code (%d-%d); got %d", class, lo, hi, rec.Code)
+	}
+}
+
+// HTTPSuccess asserts h responds to method/url with a 2xx status code.
+func HTTPSuccess(t T, h http.Handler, method, url string, body io.Reader, opts ...RequestOption) {
+	t.Helper()
+	statusClass(t, h, method, url, body, 200, 299, "success", opts...)
+}
+
+// HTTPRedirect asserts h responds to method/url with a 3xx status code.
+func HTTPRedirect(t T, h http.Handler, method, url string, body io.Reader, opts ...RequestOption) {
+	t.Helper()
+	statusClass(t, h, method, url, body, 300, 399, "redirect", opts...)
+}
+
+// HTTPClientError asserts h responds to method/url with a 4xx status code.
+func HTTPClientError(t T, h http.Handler, method, url string, body io.Reader, opts ...RequestOption) {
+	t.Helper()
+	statusClass(t, h, method, url, body, 400, 499, "client error", opts...)
+}
+
+// HTTPServerError asserts h responds to method/url with a 5xx status code.
+func HTTPServerError(t T, h http.Handler, method, url string, body io.Reader, opts ...RequestOption) {
+	t.Helper()
+	statusClass(t, h, method, url, body, 500, 599, "server error", opts...)
+}
+
+// HTTPBodyContains asserts h responds to method/url with a body containing substr.
+func HTTPBodyContains(t T, h http.Handler, method, url string, body io.Reader, substr string, opts ...RequestOption) {
+	t.Helper()
+
+	rec := do(h, method, url, body, opts...)
+	if !strings.Contains(rec.Body.String(), substr) {
+		fail(t, rec, method, url, ";; expected body to contain %q", substr)
+	}
+}
+
+// HTTPBodyEqJSON asserts h responds to method/url with a body that is
+// equivalent JSON to wantJSON. It reuses test.EqJSON for the comparison, so
+// failure reporting stays in lockstep with the rest of the package.
+func HTTPBodyEqJSON(t T, h http.Handler, method, url string, body io.Reader, wantJSON string, opts ...RequestOption) {
+	t.Helper()
+
+	rec := do(h, method, url, body, opts...)
+	test.EqJSON(t, rec.Body.String(), wantJSON,
+		test.Context("method", method),
+		test.Context("url", url),
+		test.Context("status", strconv.Itoa(rec.Code)),
+		test.Context("body", truncate(rec.Body.String())),
+	)
+}
+
+// HTTPHeader asserts h responds to method/url with header set to wantValue.
+func HTTPHeader(t T, h http.Handler, method, url string, body io.Reader, header, wantValue string, opts ...RequestOption) {
+	t.Helper()
+
+	rec := do(h, method, url, body, opts...)
+	got := rec.Header().Get(header)
+	if got != wantValue {
+		fail(t, rec, method, url, ";; expected header %q to be %q; got %q", header, wantValue, got)
+	}
+}
+
+// HTTPHeaderContains asserts h responds to method/url with header containing substr.
+func HTTPHeaderContains(t T, h http.Handler, method, url string, body io.Reader, header, substr string, opts ...RequestOption) {
+	t.Helper()
+
+	rec := do(h, method, url, body, opts...)
+	got := rec.Header().Get(header)
+	if !strings.Contains(got, substr) {
+		fail(t, rec, method, url, ";; expected header %q to contain %q; got %q", header, substr, got)
+	}
+}
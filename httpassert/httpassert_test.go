@@ -0,0 +1,212 @@
+package httpassert
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+type fakeT struct {
+	logs   []string
+	failed bool
+}
+
+func (f *fakeT) Helper() {}
+
+func (f *fakeT) Logf(format string, args ...any) {
+	f.logs = append(f.logs, fmt.Sprintf(format, args...))
+}
+
+func (f *fakeT) Fail() {
+	f.failed = true
+}
+
+func handler(status int, header, headerValue, body string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if header != "" {
+			w.Header().Set(header, headerValue)
+		}
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	})
+}
+
+func TestHTTPStatus(t *testing.T) {
+	h := handler(http.StatusTeapot, "", "", "")
+
+	ft := &fakeT{}
+	HTTPStatus(ft, h, http.MethodGet, "/", nil, http.StatusTeapot)
+	if ft.failed {
+		t.Fatalf("expected no failure on a matching status code")
+	}
+
+	ft = &fakeT{}
+	HTTPStatus(ft, h, http.MethodGet, "/", nil, http.StatusOK)
+	if !ft.failed {
+		t.Fatalf("expected failure on a mismatched status code")
+	}
+}
+
+func TestHTTPSuccess(t *testing.T) {
+	ft := &fakeT{}
+	HTTPSuccess(ft, handler(http.StatusNoContent, "", "", ""), http.MethodGet, "/", nil)
+	if ft.failed {
+		t.Fatalf("expected no failure on a 2xx status code")
+	}
+
+	ft = &fakeT{}
+	HTTPSuccess(ft, handler(http.StatusNotFound, "", "", ""), http.MethodGet, "/", nil)
+	if !ft.failed {
+		t.Fatalf("expected failure on a non-2xx status code")
+	}
+}
+
+func TestHTTPRedirect(t *testing.T) {
+	ft := &fakeT{}
+	HTTPRedirect(ft, handler(http.StatusFound, "", "", ""), http.MethodGet, "/", nil)
+	if ft.failed {
+		t.Fatalf("expected no failure on a 3xx status code")
+	}
+
+	ft = &fakeT{}
+	HTTPRedirect(ft, handler(http.StatusOK, "", "", ""), http.MethodGet, "/", nil)
+	if !ft.failed {
+		t.Fatalf("expected failure on a non-3xx status code")
+	}
+}
+
+func TestHTTPClientError(t *testing.T) {
+	ft := &fakeT{}
+	HTTPClientError(ft, handler(http.StatusForbidden, "", "", ""), http.MethodGet, "/", nil)
+	if ft.failed {
+		t.Fatalf("expected no failure on a 4xx status code")
+	}
+
+	ft = &fakeT{}
+	HTTPClientError(ft, handler(http.StatusOK, "", "", ""), http.MethodGet, "/", nil)
+	if !ft.failed {
+		t.Fatalf("expected failure on a non-4xx status code")
+	}
+}
+
+func TestHTTPServerError(t *testing.T) {
+	ft := &fakeT{}
+	HTTPServerError(ft, handler(http.StatusBadGateway, "", "", ""), http.MethodGet, "/", nil)
+	if ft.failed {
+		t.Fatalf("expected no failure on a 5xx status code")
+	}
+
+	ft = &fakeT{}
+	HTTPServerError(ft, handler(http.StatusOK, "", "", ""), http.MethodGet, "/", nil)
+	if !ft.failed {
+		t.Fatalf("expected failure on a non-5xx status code")
+	}
+}
+
+func TestHTTPBodyContains(t *testing.T) {
+	h := handler(http.StatusOK, "", "", "hello, world")
+
+	ft := &fakeT{}
+	HTTPBodyContains(ft, h, http.MethodGet, "/", nil, "world")
+	if ft.failed {
+		t.Fatalf("expected no failure when the body contains the substring")
+	}
+
+	ft = &fakeT{}
+	HTTPBodyContains(ft, h, http.MethodGet, "/", nil, "goodbye")
+	if !ft.failed {
+		t.Fatalf("expected failure when the body does not contain the substring")
+	}
+}
+
+func TestHTTPBodyEqJSON(t *testing.T) {
+	h := handler(http.StatusOK, "Content-Type", "application/json", `{"b": 2, "a": 1}`)
+
+	ft := &fakeT{}
+	HTTPBodyEqJSON(ft, h, http.MethodGet, "/", nil, `{"a": 1, "b": 2}`)
+	if ft.failed {
+		t.Fatalf("expected no failure on equivalent JSON regardless of key order")
+	}
+
+	ft = &fakeT{}
+	HTTPBodyEqJSON(ft, h, http.MethodGet, "/", nil, `{"a": 1, "b": 3}`)
+	if !ft.failed {
+		t.Fatalf("expected failure on non-equivalent JSON")
+	}
+}
+
+func TestHTTPHeader(t *testing.T) {
+	h := handler(http.StatusOK, "X-Request-Id", "abc123", "")
+
+	ft := &fakeT{}
+	HTTPHeader(ft, h, http.MethodGet, "/", nil, "X-Request-Id", "abc123")
+	if ft.failed {
+		t.Fatalf("expected no failure on a matching header")
+	}
+
+	ft = &fakeT{}
+	HTTPHeader(ft, h, http.MethodGet, "/", nil, "X-Request-Id", "other")
+	if !ft.failed {
+		t.Fatalf("expected failure on a mismatched header")
+	}
+}
+
+func TestHTTPHeaderContains(t *testing.T) {
+	h := handler(http.StatusOK, "X-Request-Id", "abc123", "")
+
+	ft := &fakeT{}
+	HTTPHeaderContains(ft, h, http.MethodGet, "/", nil, "X-Request-Id", "bc12")
+	if ft.failed {
+		t.Fatalf("expected no failure when the header contains the substring")
+	}
+
+	ft = &fakeT{}
+	HTTPHeaderContains(ft, h, http.MethodGet, "/", nil, "X-Request-Id", "zzz")
+	if !ft.failed {
+		t.Fatalf("expected failure when the header does not contain the substring")
+	}
+}
+
+func TestRequestOptions(t *testing.T) {
+	var gotHeader, gotCookie string
+	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotHeader = r.Header.Get("X-Trace")
+		if c, err := r.Cookie("session"); err == nil {
+			gotCookie = c.Value
+		}
+		w.WriteHeader(http.StatusOK)
+	})
+
+	ft := &fakeT{}
+	HTTPStatus(ft, h, http.MethodGet, "/", nil, http.StatusOK,
+		Header("X-Trace", "xyz"),
+		Cookie(&http.Cookie{Name: "session", Value: "s1"}),
+	)
+
+	if ft.failed {
+		t.Fatalf("expected no failure")
+	}
+	if gotHeader != "xyz" {
+		t.Fatalf("expected Header option to set X-Trace, got %q", gotHeader)
+	}
+	if gotCookie != "s1" {
+		t.Fatalf("expected Cookie option to attach the session cookie, got %q", gotCookie)
+	}
+}
+
+func TestFailureLogsRequestDetails(t *testing.T) {
+	ft := &fakeT{}
+	HTTPStatus(ft, handler(http.StatusOK, "", "", "unexpected body"), http.MethodGet, "/widgets", nil, http.StatusTeapot)
+
+	if !ft.failed {
+		t.Fatalf("expected failure")
+	}
+	joined := strings.Join(ft.logs, "")
+	if !strings.Contains(joined, "/widgets") {
+		t.Fatalf("expected failure logs to include the request url, got:\n%s", joined)
+	}
+	if !strings.Contains(joined, "unexpected body") {
+		t.Fatalf("expected failure logs to include the response body, got:\n%s", joined)
+	}
+}
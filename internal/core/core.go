@@ -0,0 +1,257 @@
+// Package core holds the comparison and failure-reporting logic shared by
+// the soft-failing test package and the fail-fast must package, so the two
+// public surfaces cannot drift out of sync with each other.
+package core
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// Reporter is the subset of testing.TB needed to record a failure and keep
+// the test running. It is satisfied by the test package's T.
+type Reporter interface {
+	Helper()
+	Logf(format string, args ...any)
+	Fail()
+}
+
+// FailNower is the subset of testing.TB needed to record a failure and abort
+// the test immediately. It is satisfied by the must package's T.
+type FailNower interface {
+	Helper()
+	Logf(format string, args ...any)
+	FailNow()
+}
+
+// contextKV is a single key/value pair attached via a Context option.
+type contextKV struct {
+	Key, Value string
+}
+
+// Config accumulates the Option values passed to an assertion.
+type Config struct {
+	msg      string
+	context  []contextKV
+	reporter DiffReporter
+}
+
+// Option customizes how a single assertion call reports failure.
+type Option func(*Config)
+
+// Sprintf sets a formatted message logged ahead of an assertion's own
+// failure message, so table-driven tests can identify which case failed.
+func Sprintf(format string, args ...any) Option {
+	return func(c *Config) { c.msg = fmt.Sprintf(format, args...) }
+}
+
+// Context attaches a key/value pair logged alongside an assertion failure.
+func Context(key, value string) Option {
+	return func(c *Config) { c.context = append(c.context, contextKV{key, value}) }
+}
+
+// WithReporter overrides the DiffReporter used by a single assertion call,
+// without changing the package-wide default set via SetReporter.
+func WithReporter(r DiffReporter) Option {
+	return func(c *Config) { c.reporter = r }
+}
+
+func newConfig(opts []Option) *Config {
+	cfg := new(Config)
+	for _, opt := range opts {
+		opt(cfg)
+	}
+	return cfg
+}
+
+type logger interface {
+	Logf(format string, args ...any)
+}
+
+func logConfig(t logger, cfg *Config) {
+	if cfg.msg != "" {
+		t.Logf(strings.TrimSpace(cfg.msg) + "\n")
+	}
+	for _, kv := range cfg.context {
+		t.Logf("%s: %s\n", kv.Key, kv.Value)
+	}
+}
+
+// Fail logs msg (along with any Option-provided context) and marks t as
+// failed, allowing the test to continue.
+func Fail(t Reporter, opts []Option, msg string, args ...any) {
+	cfg := newConfig(opts)
+	logConfig(t, cfg)
+	s := fmt.Sprintf(msg, args...)
+	t.Logf(strings.TrimSpace(s) + "\n")
+	t.Fail()
+}
+
+// FailNow logs msg (along with any Option-provided context) and aborts t
+// immediately via t.FailNow.
+func FailNow(t FailNower, opts []Option, msg string, args ...any) {
+	cfg := newConfig(opts)
+	logConfig(t, cfg)
+	s := fmt.Sprintf(msg, args...)
+	t.Logf(strings.TrimSpace(s) + "\n")
+	t.FailNow()
+}
+
+// DiffReporter produces a human-readable description of the difference
+// between a and b. It is the extension point behind the Diff helper used by
+// every assertion in the test and must packages.
+type DiffReporter interface {
+	Diff(a, b any) string
+}
+
+// cmpReporter is the default DiffReporter, backed by cmp.Diff. It falls back
+// to printing the Go string values of both types when cmp panics (e.g.
+// unexported fields, cycles).
+type cmpReporter struct{}
+
+func (cmpReporter) Diff(a, b any) (s string) {
+	defer func() {
+		if r := recover(); r != nil {
+			s = fmt.Sprintf("difference!\na: %#v\nb: %#v\n", a, b)
+		}
+	}()
+	s = "difference!\n" + cmp.Diff(a, b)
+	return
+}
+
+var activeReporter DiffReporter = cmpReporter{}
+
+// SetReporter overrides the DiffReporter used by every assertion in the test
+// and must packages. Passing nil restores the default cmp.Diff-based
+// reporter.
+func SetReporter(r DiffReporter) {
+	if r == nil {
+		r = cmpReporter{}
+	}
+	activeReporter = r
+}
+
+// Diff creates a diff of a and b using the active DiffReporter, or the one
+// supplied via a WithReporter option.
+func Diff[A, B any](a A, b B, opts []Option) string {
+	cfg := newConfig(opts)
+	r := activeReporter
+	if cfg.reporter != nil {
+		r = cfg.reporter
+	}
+	return r.Diff(a, b)
+}
+
+// Equal compares a and b using cmp.Equal if possible, falling back to
+// reflect.DeepEqual (e.g. contains unexported fields).
+func Equal[A, B any](a A, b B) (result bool) {
+	defer func() {
+		if r := recover(); r != nil {
+			result = reflect.DeepEqual(a, b)
+		}
+	}()
+	result = cmp.Equal(a, b)
+	return
+}
+
+// Contains returns whether item is present in slice, comparing elements with
+// the == operator.
+func Contains[C comparable](slice []C, item C) bool {
+	found := false
+	for i := 0; i < len(slice); i++ {
+		if slice[i] == item {
+			found = true
+			break
+		}
+	}
+	return found
+}
+
+// ContainsFunc returns whether item is present in slice, comparing elements
+// with eq.
+func ContainsFunc[A any](slice []A, item A, eq func(a, b A) bool) bool {
+	found := false
+	for i := 0; i < len(slice); i++ {
+		if eq(slice[i], item) {
+			found = true
+			break
+		}
+	}
+	return found
+}
+
+// MultisetDiff reports, using eq to compare elements, which elements of b are
+// not matched in a ("extra") and which elements of a are not matched in b
+// ("missing"), respecting multiplicity.
+func MultisetDiff[A any](a, b []A, eq func(A, A) bool) (extra, missing []A) {
+	usedA := make([]bool, len(a))
+	usedB := make([]bool, len(b))
+
+	for i := range a {
+		for j := range b {
+			if usedB[j] {
+				continue
+			}
+			if eq(a[i], b[j]) {
+				usedA[i] = true
+				usedB[j] = true
+				break
+			}
+		}
+	}
+
+	for i, used := range usedA {
+		if !used {
+			missing = append(missing, a[i])
+		}
+	}
+	for j, used := range usedB {
+		if !used {
+			extra = append(extra, b[j])
+		}
+	}
+	return extra, missing
+}
+
+// MultisetDiffCmp is like MultisetDiff but for comparable element types,
+// using an O(n) hash-bucket algorithm instead of an O(n*m) comparison.
+func MultisetDiffCmp[C comparable](a, b []C) (extra, missing []C) {
+	counts := make(map[C]int, len(a))
+	for _, x := range a {
+		counts[x]++
+	}
+	for _, x := range b {
+		if counts[x] > 0 {
+			counts[x]--
+		} else {
+			extra = append(extra, x)
+		}
+	}
+	for x, n := range counts {
+		for i := 0; i < n; i++ {
+			missing = append(missing, x)
+		}
+	}
+	return extra, missing
+}
+
+// LogSetDiff logs the extra/missing elements produced by MultisetDiff or
+// MultisetDiffCmp, in the form used by SetEq and its Cmp variant.
+func LogSetDiff[A any](t Logger, extra, missing []A) {
+	if len(missing) != 0 {
+		t.Logf("missing elements (in a, not in b): %#v\n", missing)
+	}
+	if len(extra) != 0 {
+		t.Logf("extra elements (in b, not in a): %#v\n", extra)
+	}
+}
+
+// Logger is the subset of testing.TB needed to log a message without marking
+// the test failed. It is satisfied by both the test package's T and the must
+// package's T.
+type Logger interface {
+	Logf(format string, args ...any)
+}
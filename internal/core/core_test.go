@@ -0,0 +1,117 @@
+package core
+
+import (
+	"reflect"
+	"testing"
+)
+
+func sortedStrings(ss []string) []string {
+	out := append([]string(nil), ss...)
+	for i := 1; i < len(out); i++ {
+		for j := i; j > 0 && out[j-1] > out[j]; j-- {
+			out[j-1], out[j] = out[j], out[j-1]
+		}
+	}
+	return out
+}
+
+// sameElements compares two slices ignoring the nil vs empty distinction,
+// since MultisetDiff/MultisetDiffCmp only ever append.
+func sameElements[A any](got, want []A) bool {
+	if len(got) == 0 && len(want) == 0 {
+		return true
+	}
+	return reflect.DeepEqual(got, want)
+}
+
+func TestMultisetDiff(t *testing.T) {
+	cases := []struct {
+		name        string
+		a, b        []string
+		wantExtra   []string
+		wantMissing []string
+	}{
+		{name: "equal", a: []string{"x", "y"}, b: []string{"y", "x"}},
+		{name: "empty both"},
+		{name: "empty a", b: []string{"x"}, wantExtra: []string{"x"}},
+		{name: "empty b", a: []string{"x"}, wantMissing: []string{"x"}},
+		{
+			name:        "duplicates",
+			a:           []string{"x", "x", "y"},
+			b:           []string{"x", "y", "y"},
+			wantExtra:   []string{"y"},
+			wantMissing: []string{"x"},
+		},
+	}
+
+	eq := func(a, b string) bool { return a == b }
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			extra, missing := MultisetDiff(tc.a, tc.b, eq)
+			if !sameElements(sortedStrings(extra), sortedStrings(tc.wantExtra)) {
+				t.Fatalf("extra: got %#v, want %#v", extra, tc.wantExtra)
+			}
+			if !sameElements(sortedStrings(missing), sortedStrings(tc.wantMissing)) {
+				t.Fatalf("missing: got %#v, want %#v", missing, tc.wantMissing)
+			}
+		})
+	}
+}
+
+func TestMultisetDiffCmp(t *testing.T) {
+	cases := []struct {
+		name        string
+		a, b        []int
+		wantExtra   []int
+		wantMissing []int
+	}{
+		{name: "equal", a: []int{1, 2}, b: []int{2, 1}},
+		{name: "empty both"},
+		{name: "empty a", b: []int{1}, wantExtra: []int{1}},
+		{name: "empty b", a: []int{1}, wantMissing: []int{1}},
+		{
+			name:        "duplicates",
+			a:           []int{1, 1, 2},
+			b:           []int{1, 2, 2},
+			wantExtra:   []int{2},
+			wantMissing: []int{1},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			extra, missing := MultisetDiffCmp(tc.a, tc.b)
+			if !sameElements(extra, tc.wantExtra) {
+				t.Fatalf("extra: got %#v, want %#v", extra, tc.wantExtra)
+			}
+			if !sameElements(missing, tc.wantMissing) {
+				t.Fatalf("missing: got %#v, want %#v", missing, tc.wantMissing)
+			}
+		})
+	}
+}
+
+// TestMultisetDiffAgree checks that the O(n*m) comparator-based algorithm and
+// the O(n) hash-bucket algorithm agree on every case, since SetEq and SetEqCmp
+// must produce the same verdict for comparable element types.
+func TestMultisetDiffAgree(t *testing.T) {
+	cases := [][2][]int{
+		{nil, nil},
+		{[]int{1, 2, 3}, []int{3, 2, 1}},
+		{[]int{1, 1, 2}, []int{1, 2, 2}},
+		{[]int{1}, []int{2}},
+	}
+
+	eq := func(a, b int) bool { return a == b }
+
+	for _, tc := range cases {
+		a, b := tc[0], tc[1]
+		extraFunc, missingFunc := MultisetDiff(a, b, eq)
+		extraCmp, missingCmp := MultisetDiffCmp(a, b)
+		if len(extraFunc) != len(extraCmp) || len(missingFunc) != len(missingCmp) {
+			t.Fatalf("algorithms disagree for a=%v b=%v: func extra=%v missing=%v, cmp extra=%v missing=%v",
+				a, b, extraFunc, missingFunc, extraCmp, missingCmp)
+		}
+	}
+}
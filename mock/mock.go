@@ -0,0 +1,329 @@
+// Package mock provides a minimal expectation-based mocking facility,
+// modeled after testify's mock package. Embed Mock in a hand-written fake,
+// set expectations with On, and have each method of the fake call Called
+// (or MethodCalled) to record the call and obtain the configured return
+// values.
+package mock
+
+import (
+	"fmt"
+	"reflect"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/shoenig/test/internal/core"
+)
+
+// Anything is used in an On(...) expectation to indicate that an argument
+// can be any value.
+const Anything = "mock.Anything"
+
+// AnythingOfTypeArgument matches any value whose reflect.Type.String()
+// equals the given type name, e.g. AnythingOfType("*http.Request").
+type AnythingOfTypeArgument string
+
+// AnythingOfType returns a matcher for any value of the named type.
+func AnythingOfType(t string) AnythingOfTypeArgument {
+	return AnythingOfTypeArgument(t)
+}
+
+// argumentMatcher matches an argument via an arbitrary predicate, as
+// produced by MatchedBy.
+type argumentMatcher struct {
+	fn reflect.Value
+}
+
+// MatchedBy returns a matcher that calls fn(arg) to decide whether arg
+// matches. fn must be a func(T) bool for some type T.
+func MatchedBy(fn any) argumentMatcher {
+	v := reflect.ValueOf(fn)
+	t := v.Type()
+	if v.Kind() != reflect.Func || t.NumIn() != 1 || t.NumOut() != 1 || t.Out(0).Kind() != reflect.Bool {
+		panic("mock: MatchedBy requires a func(T) bool")
+	}
+	return argumentMatcher{fn: v}
+}
+
+func (m argumentMatcher) matches(arg any) bool {
+	in := m.fn.Type().In(0)
+	var argVal reflect.Value
+	if arg == nil {
+		argVal = reflect.Zero(in)
+	} else {
+		argVal = reflect.ValueOf(arg)
+		if !argVal.Type().AssignableTo(in) {
+			return false
+		}
+	}
+	return m.fn.Call([]reflect.Value{argVal})[0].Bool()
+}
+
+// Arguments holds a list of expected or actual call arguments.
+type Arguments []any
+
+// Get returns the i'th argument.
+func (args Arguments) Get(i int) any { return args[i] }
+
+// Int returns the i'th argument as an int.
+func (args Arguments) Int(i int) int { return args[i].(int) }
+
+// Bool returns the i'th argument as a bool.
+func (args Arguments) Bool(i int) bool { return args[i].(bool) }
+
+// Error returns the i'th argument as an error, or nil if it is nil.
+func (args Arguments) Error(i int) error {
+	if args[i] == nil {
+		return nil
+	}
+	return args[i].(error)
+}
+
+// String returns the i'th argument as a string if an index is given, or a
+// printable representation of the full argument list if not.
+func (args Arguments) String(index ...int) string {
+	if len(index) == 0 {
+		parts := make([]string, len(args))
+		for i, a := range args {
+			parts[i] = fmt.Sprintf("%#v", a)
+		}
+		return "(" + strings.Join(parts, ", ") + ")"
+	}
+	return args[index[0]].(string)
+}
+
+// matches reports whether actual satisfies the expectations in args,
+// honoring the Anything, AnythingOfType, and MatchedBy matchers.
+func (args Arguments) matches(actual Arguments) bool {
+	if len(args) != len(actual) {
+		return false
+	}
+	for i, expected := range args {
+		if !argMatches(expected, actual[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func argMatches(expected, actual any) bool {
+	switch e := expected.(type) {
+	case string:
+		if e == Anything {
+			return true
+		}
+	case AnythingOfTypeArgument:
+		return actual != nil && reflect.TypeOf(actual).String() == string(e)
+	case argumentMatcher:
+		return e.matches(actual)
+	}
+	return core.Equal(expected, actual)
+}
+
+// Call represents an expectation set up via Mock.On, or (once Method is
+// set and Arguments recorded) a call that actually took place.
+type Call struct {
+	Parent *Mock
+
+	Method          string
+	Arguments       Arguments
+	ReturnArguments Arguments
+
+	repeatability int // 0 means unlimited, otherwise the call is exhausted after this many uses
+	totalCalls    int
+	optional      bool
+	waitFor       time.Duration
+	runFn         func(Arguments)
+}
+
+// Return sets the values returned by Called/MethodCalled when this
+// expectation is matched.
+func (c *Call) Return(returnArguments ...any) *Call {
+	c.ReturnArguments = returnArguments
+	return c
+}
+
+// Once limits this expectation to a single use.
+func (c *Call) Once() *Call {
+	return c.Times(1)
+}
+
+// Times limits this expectation to n uses.
+func (c *Call) Times(n int) *Call {
+	c.repeatability = n
+	return c
+}
+
+// Maybe marks this expectation as optional; AssertExpectations will not fail
+// if it is never matched.
+func (c *Call) Maybe() *Call {
+	c.optional = true
+	return c
+}
+
+// Run registers a side-effect function invoked with the actual call
+// arguments whenever this expectation is matched.
+func (c *Call) Run(fn func(Arguments)) *Call {
+	c.runFn = fn
+	return c
+}
+
+// After delays the return of this call by d, useful for simulating slow
+// dependencies.
+func (c *Call) After(d time.Duration) *Call {
+	c.waitFor = d
+	return c
+}
+
+// failer is the subset of testing.TB needed to fail a test immediately; it
+// is satisfied by testing.TB and the must package's T (but not the test
+// package's T, which has Fail rather than FailNow).
+type failer interface {
+	Helper()
+	Logf(format string, args ...any)
+	FailNow()
+}
+
+// Mock is embedded in a hand-written fake to give it expectation setting,
+// call recording, and argument matching. It is safe for concurrent use.
+type Mock struct {
+	mu            sync.Mutex
+	expectedCalls []*Call
+	actualCalls   []Call
+	t             failer
+}
+
+// Test routes failures detected by Called/MethodCalled (an unexpected call
+// with no matching expectation) to t.FailNow instead of panicking.
+func (m *Mock) Test(t failer) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.t = t
+}
+
+// On registers an expectation that method will be called with args, and
+// returns the *Call for further configuration (Return, Once, Maybe, ...).
+func (m *Mock) On(method string, args ...any) *Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	c := &Call{Parent: m, Method: method, Arguments: args}
+	m.expectedCalls = append(m.expectedCalls, c)
+	return c
+}
+
+func (m *Mock) findExpectedCall(method string, args Arguments) *Call {
+	for _, c := range m.expectedCalls {
+		if c.Method != method {
+			continue
+		}
+		if c.repeatability > 0 && c.totalCalls >= c.repeatability {
+			continue
+		}
+		if c.Arguments.matches(args) {
+			return c
+		}
+	}
+	return nil
+}
+
+// Called records a call to the calling method (determined via runtime.Caller)
+// with args, and returns the configured return values. It panics (or, if
+// Test was called, fails the test) if no expectation matches.
+func (m *Mock) Called(args ...any) Arguments {
+	method := "unknown"
+	if pc, _, _, ok := runtime.Caller(1); ok {
+		if f := runtime.FuncForPC(pc); f != nil {
+			method = methodName(f.Name())
+		}
+	}
+	return m.MethodCalled(method, args...)
+}
+
+// MethodCalled is like Called but with an explicit method name, useful when
+// the caller cannot be determined automatically.
+func (m *Mock) MethodCalled(method string, args ...any) Arguments {
+	m.mu.Lock()
+
+	call := m.findExpectedCall(method, args)
+	if call == nil {
+		t := m.t
+		m.mu.Unlock()
+		msg := fmt.Sprintf("mock: unexpected call to %s%s; no matching expectation set via On", method, Arguments(args).String())
+		if t != nil {
+			core.FailNow(t, nil, msg)
+		}
+		panic(msg)
+	}
+
+	call.totalCalls++
+	waitFor := call.waitFor
+	runFn := call.runFn
+	ret := call.ReturnArguments
+	m.actualCalls = append(m.actualCalls, Call{Method: method, Arguments: args})
+
+	m.mu.Unlock()
+
+	if waitFor > 0 {
+		time.Sleep(waitFor)
+	}
+	if runFn != nil {
+		runFn(args)
+	}
+
+	return ret
+}
+
+// Unmet returns the non-optional expectations set via On that have not yet
+// been satisfied.
+func (m *Mock) Unmet() []*Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	var unmet []*Call
+	for _, c := range m.expectedCalls {
+		if c.optional {
+			continue
+		}
+		if c.totalCalls == 0 || (c.repeatability > 0 && c.totalCalls < c.repeatability) {
+			unmet = append(unmet, c)
+		}
+	}
+	return unmet
+}
+
+// Calls returns a snapshot of the calls recorded so far via Called or
+// MethodCalled.
+func (m *Mock) Calls() []Call {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	out := make([]Call, len(m.actualCalls))
+	copy(out, m.actualCalls)
+	return out
+}
+
+// WasCalled reports whether method was called with arguments matching args
+// (honoring Anything, AnythingOfType, and MatchedBy).
+func (m *Mock) WasCalled(method string, args ...any) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	for _, c := range m.actualCalls {
+		if c.Method == method && Arguments(args).matches(c.Arguments) {
+			return true
+		}
+	}
+	return false
+}
+
+// methodName strips the package/receiver prefix and any generated suffix
+// from a runtime function name, e.g. "pkg.(*Fake).Get-fm" -> "Get".
+func methodName(full string) string {
+	name := full
+	if i := strings.LastIndex(name, "."); i >= 0 {
+		name = name[i+1:]
+	}
+	return strings.TrimSuffix(name, "-fm")
+}
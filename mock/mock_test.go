@@ -0,0 +1,150 @@
+package mock
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+)
+
+type fakeT struct {
+	failedNow bool
+}
+
+func (f *fakeT) Helper()                        {}
+func (f *fakeT) Logf(format string, args ...any) {}
+func (f *fakeT) FailNow()                        { f.failedNow = true }
+
+func TestMock_MatcherPrecedence(t *testing.T) {
+	var m Mock
+
+	m.On("Get", Anything, AnythingOfType("int"), MatchedBy(func(s string) bool { return len(s) == 3 })).
+		Return("ok")
+
+	ret := m.MethodCalled("Get", "whatever", 5, "abc")
+	if ret.String(0) != "ok" {
+		t.Fatalf("unexpected return value: %v", ret)
+	}
+
+	if !m.WasCalled("Get", "whatever", 5, "abc") {
+		t.Fatalf("expected call to match via Anything/AnythingOfType/MatchedBy")
+	}
+}
+
+func TestMock_MatchedByWrongType(t *testing.T) {
+	var m Mock
+
+	m.On("Get", MatchedBy(func(n int) bool { return n > 0 })).Return()
+
+	if m.findExpectedCall("Get", Arguments{"not an int"}) != nil {
+		t.Fatalf("expected MatchedBy to reject an argument of the wrong type")
+	}
+	if m.findExpectedCall("Get", Arguments{5}) == nil {
+		t.Fatalf("expected MatchedBy to accept a matching argument")
+	}
+}
+
+func TestMock_AnythingOfType(t *testing.T) {
+	var m Mock
+	m.On("Save", AnythingOfType("string")).Return()
+
+	if m.findExpectedCall("Save", Arguments{42}) != nil {
+		t.Fatalf("expected AnythingOfType(\"string\") to reject an int")
+	}
+	if m.findExpectedCall("Save", Arguments{"x"}) == nil {
+		t.Fatalf("expected AnythingOfType(\"string\") to accept a string")
+	}
+}
+
+func TestMock_UnmetExpectations(t *testing.T) {
+	var m Mock
+	m.On("Required").Return()
+	m.On("Optional").Return().Maybe()
+
+	unmet := m.Unmet()
+	if len(unmet) != 1 || unmet[0].Method != "Required" {
+		t.Fatalf("expected only the non-optional expectation to be unmet, got %v", unmet)
+	}
+
+	m.MethodCalled("Required")
+	if len(m.Unmet()) != 0 {
+		t.Fatalf("expected no unmet expectations after the call")
+	}
+}
+
+func TestMock_Times(t *testing.T) {
+	var m Mock
+	m.On("Get").Return().Times(2)
+
+	m.MethodCalled("Get")
+	if m.findExpectedCall("Get", nil) == nil {
+		t.Fatalf("expected the expectation to still be active after one of two uses")
+	}
+	m.MethodCalled("Get")
+	if m.findExpectedCall("Get", nil) != nil {
+		t.Fatalf("expected the expectation to be exhausted after two of two uses")
+	}
+}
+
+func TestMock_UnexpectedCallPanics(t *testing.T) {
+	var m Mock
+
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("expected an unexpected call with no Test(t) set to panic")
+		}
+	}()
+	m.MethodCalled("Missing")
+}
+
+func TestMock_UnexpectedCallFailsTest(t *testing.T) {
+	var m Mock
+	ft := &fakeT{}
+	m.Test(ft)
+
+	// MethodCalled still panics after routing the failure through FailNow, so
+	// a non-testing.TB fake (which can't runtime.Goexit) needs to recover.
+	defer func() {
+		recover()
+		if !ft.failedNow {
+			t.Fatalf("expected an unexpected call to invoke FailNow once Test(t) is set")
+		}
+	}()
+	m.MethodCalled("Missing")
+}
+
+// TestMock_ConcurrentCalls exercises Called/MethodCalled and Calls/WasCalled
+// from many goroutines at once; it is meant to be run with -race.
+func TestMock_ConcurrentCalls(t *testing.T) {
+	var m Mock
+	m.On("Get", AnythingOfType("int")).Return()
+
+	const n = 50
+	var wg sync.WaitGroup
+	wg.Add(n)
+	for i := 0; i < n; i++ {
+		i := i
+		go func() {
+			defer wg.Done()
+			m.MethodCalled("Get", i)
+		}()
+	}
+	wg.Wait()
+
+	if got := len(m.Calls()); got != n {
+		t.Fatalf("expected %d recorded calls, got %d", n, got)
+	}
+	for i := 0; i < n; i++ {
+		if !m.WasCalled("Get", i) {
+			t.Fatalf("expected call with argument %d to have been recorded", i)
+		}
+	}
+}
+
+func TestArguments_String(t *testing.T) {
+	args := Arguments{"a", 1, true}
+	got := args.String()
+	want := fmt.Sprintf("(%#v, %#v, %#v)", "a", 1, true)
+	if got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
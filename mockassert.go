@@ -0,0 +1,27 @@
+package test
+
+import "github.com/shoenig/test/mock"
+
+// AssertExpectations asserts every non-optional expectation set on m via
+// On(...) has been satisfied.
+func AssertExpectations(t T, m *mock.Mock, opts ...Option) {
+	t.Helper()
+
+	if unmet := m.Unmet(); len(unmet) != 0 {
+		for _, c := range unmet {
+			t.Logf("unmet expectation: %s%s\n", c.Method, c.Arguments.String())
+		}
+		fail(t, opts, ";; expected all mock expectations to be met")
+	}
+}
+
+// AssertCalled asserts m was called with method and args, honoring the
+// Anything, AnythingOfType, and MatchedBy matchers.
+func AssertCalled(t T, m *mock.Mock, method string, args []any, opts ...Option) {
+	t.Helper()
+
+	if !m.WasCalled(method, args...) {
+		t.Logf("calls recorded: %v\n", m.Calls())
+		fail(t, opts, ";; expected mock to have been called with %s%s", method, mock.Arguments(args).String())
+	}
+}
@@ -0,0 +1,97 @@
+package test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/shoenig/test/mock"
+)
+
+type fakeMockT struct {
+	logs   []string
+	failed bool
+}
+
+func (f *fakeMockT) Helper() {}
+
+func (f *fakeMockT) Logf(format string, args ...any) {
+	f.logs = append(f.logs, format)
+}
+
+func (f *fakeMockT) Fail() {
+	f.failed = true
+}
+
+func TestAssertExpectations(t *testing.T) {
+	m := new(mock.Mock)
+	m.On("Get", "key").Return("value")
+
+	ft := &fakeMockT{}
+	AssertExpectations(ft, m)
+	if !ft.failed {
+		t.Fatalf("expected failure since the expectation was never met")
+	}
+
+	m.MethodCalled("Get", "key")
+
+	ft = &fakeMockT{}
+	AssertExpectations(ft, m)
+	if ft.failed {
+		t.Fatalf("expected no failure once the expectation was met")
+	}
+}
+
+func TestAssertExpectations_Maybe(t *testing.T) {
+	m := new(mock.Mock)
+	m.On("Get", "key").Return("value").Maybe()
+
+	ft := &fakeMockT{}
+	AssertExpectations(ft, m)
+	if ft.failed {
+		t.Fatalf("expected no failure for an unmet optional expectation")
+	}
+}
+
+func TestAssertCalled(t *testing.T) {
+	m := new(mock.Mock)
+	m.On("Get", "key").Return("value")
+	m.MethodCalled("Get", "key")
+
+	ft := &fakeMockT{}
+	AssertCalled(ft, m, "Get", []any{"key"})
+	if ft.failed {
+		t.Fatalf("expected no failure since the call was recorded")
+	}
+
+	ft = &fakeMockT{}
+	AssertCalled(ft, m, "Get", []any{"other"})
+	if !ft.failed {
+		t.Fatalf("expected failure since no matching call was recorded")
+	}
+}
+
+func TestAssertCalled_Matchers(t *testing.T) {
+	m := new(mock.Mock)
+	m.On("Get", mock.Anything).Return("value")
+	m.MethodCalled("Get", "whatever")
+
+	ft := &fakeMockT{}
+	AssertCalled(ft, m, "Get", []any{mock.Anything})
+	if ft.failed {
+		t.Fatalf("expected Anything to match the recorded call")
+	}
+}
+
+func TestAssertCalled_LogsRecordedCallsOnFailure(t *testing.T) {
+	m := new(mock.Mock)
+	m.On("Get", "key").Return("value")
+	m.MethodCalled("Get", "key")
+
+	ft := &fakeMockT{}
+	AssertCalled(ft, m, "Get", []any{"missing"})
+
+	joined := strings.Join(ft.logs, "")
+	if !strings.Contains(joined, "calls recorded") {
+		t.Fatalf("expected failure logs to include the recorded calls, got:\n%s", joined)
+	}
+}
@@ -0,0 +1,107 @@
+package must
+
+import (
+	"errors"
+	"runtime"
+	"sync"
+	"testing"
+)
+
+// fakeT mimics testing.T's FailNow semantics (abort the calling goroutine via
+// runtime.Goexit, running deferred calls but never returning to the caller),
+// so tests can prove an assertion stops execution instead of merely logging.
+type fakeT struct {
+	mu        sync.Mutex
+	logs      []string
+	failedNow bool
+}
+
+func (f *fakeT) Helper() {}
+
+func (f *fakeT) Logf(format string, args ...any) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.logs = append(f.logs, format)
+}
+
+func (f *fakeT) FailNow() {
+	f.mu.Lock()
+	f.failedNow = true
+	f.mu.Unlock()
+	runtime.Goexit()
+}
+
+// runToCompletion runs fn on its own goroutine (since FailNow calls
+// runtime.Goexit) and reports whether fn ran to completion without FailNow
+// being called partway through.
+func runToCompletion(fn func()) (completed bool) {
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		fn()
+		completed = true
+	}()
+	wg.Wait()
+	return completed
+}
+
+func TestNoError_FailNowOnError(t *testing.T) {
+	ft := &fakeT{}
+
+	completed := runToCompletion(func() {
+		NoError(ft, errors.New("boom"))
+	})
+
+	if !ft.failedNow {
+		t.Fatalf("expected NoError to call FailNow on a non-nil error")
+	}
+	if completed {
+		t.Fatalf("expected FailNow to abort the goroutine instead of returning")
+	}
+}
+
+func TestNoError_NoFailureOnNil(t *testing.T) {
+	ft := &fakeT{}
+
+	completed := runToCompletion(func() {
+		NoError(ft, nil)
+	})
+
+	if ft.failedNow {
+		t.Fatalf("expected no FailNow on a nil error")
+	}
+	if !completed {
+		t.Fatalf("expected NoError to return normally on a nil error")
+	}
+}
+
+func TestEq_FailNowOnMismatch(t *testing.T) {
+	ft := &fakeT{}
+
+	completed := runToCompletion(func() {
+		Eq(ft, 1, 2)
+	})
+
+	if !ft.failedNow {
+		t.Fatalf("expected Eq to call FailNow on a mismatch")
+	}
+	if completed {
+		t.Fatalf("expected FailNow to abort the goroutine instead of returning")
+	}
+}
+
+func TestEq_NoFailureWhenEqual(t *testing.T) {
+	ft := &fakeT{}
+
+	completed := runToCompletion(func() {
+		Eq(ft, 1, 1)
+	})
+
+	if ft.failedNow {
+		t.Fatalf("expected no FailNow when the values are equal")
+	}
+	if !completed {
+		t.Fatalf("expected Eq to return normally on a match")
+	}
+}
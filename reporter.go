@@ -0,0 +1,262 @@
+package test
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+	"unsafe"
+
+	"github.com/shoenig/test/internal/core"
+)
+
+// Reporter produces a human-readable description of the difference between
+// a and b. It is the extension point behind the diff helper used by every
+// assertion in this package (and the must package).
+//
+// The default Reporter is backed by cmp.Diff; use NewDeepReporter for a
+// path-based alternative that works even when cmp panics (e.g. on
+// unexported fields or cycles).
+type Reporter = core.DiffReporter
+
+// SetReporter overrides the Reporter used by every assertion in the test and
+// must packages. Passing nil restores the default cmp.Diff-based reporter.
+func SetReporter(r Reporter) {
+	core.SetReporter(r)
+}
+
+const deepReporterMaxLen = 80
+
+// DeepReporter is a Reporter that walks two values in lockstep via
+// reflection and reports one line per differing leaf, in the form
+// "path: got=<value> want=<value>". It is modeled after go-test/deep and is
+// a more readable alternative to the default cmp.Diff-based Reporter for
+// deeply nested structs.
+type DeepReporter struct {
+	// MaxLen truncates long strings and byte slices to this many characters.
+	// A value <= 0 uses the default of 80 (the same as NewDeepReporter).
+	MaxLen int
+}
+
+// NewDeepReporter creates a DeepReporter with sane defaults.
+func NewDeepReporter() *DeepReporter {
+	return &DeepReporter{MaxLen: deepReporterMaxLen}
+}
+
+// Diff implements Reporter.
+func (d *DeepReporter) Diff(a, b any) string {
+	var lines []string
+	w := &deepWalker{
+		maxLen:  d.maxLenOrDefault(),
+		visited: make(map[[2]unsafe.Pointer]bool),
+	}
+	w.walk("", addressable(reflect.ValueOf(a)), addressable(reflect.ValueOf(b)), &lines)
+	if len(lines) == 0 {
+		return ""
+	}
+	return "difference!\n" + strings.Join(lines, "\n")
+}
+
+// addressable copies v into a new, addressable value of the same type, so
+// unexport can read unexported fields off it. The top-level a/b passed to
+// Diff are almost always non-addressable (e.g. a plain struct value, the
+// common case for test.Eq(t, got, want)), unlike the struct fields walk
+// later reaches via a.Field(i), which are addressable as soon as the struct
+// itself is.
+func addressable(v reflect.Value) reflect.Value {
+	if !v.IsValid() {
+		return v
+	}
+	p := reflect.New(v.Type())
+	p.Elem().Set(v)
+	return p.Elem()
+}
+
+func (d *DeepReporter) maxLenOrDefault() int {
+	if d.MaxLen <= 0 {
+		return deepReporterMaxLen
+	}
+	return d.MaxLen
+}
+
+type deepWalker struct {
+	maxLen  int
+	visited map[[2]unsafe.Pointer]bool
+}
+
+// referenceKey returns the pointer identity of v, for the reference kinds
+// (Ptr, Map, Slice) that can form a cycle, and ok=false otherwise (including
+// for a nil v of one of those kinds, which can't recurse into itself).
+func referenceKey(v reflect.Value) (key unsafe.Pointer, ok bool) {
+	switch v.Kind() {
+	case reflect.Ptr, reflect.Map, reflect.Slice:
+		if v.IsNil() {
+			return nil, false
+		}
+		return unsafe.Pointer(v.Pointer()), true
+	default:
+		return nil, false
+	}
+}
+
+// visit registers a/b's pointer identity pair (if they have one) the first
+// time it's seen, and reports whether this pair was already visited. This is
+// what stops walk from recursing forever on a cyclic structure, whether the
+// cycle runs through a literal pointer or through an interface-boxed value
+// such as a self-referential map[string]any.
+func (w *deepWalker) visit(a, b reflect.Value) (alreadyVisited bool) {
+	ka, ok := referenceKey(a)
+	if !ok {
+		return false
+	}
+	kb, ok := referenceKey(b)
+	if !ok {
+		return false
+	}
+	key := [2]unsafe.Pointer{ka, kb}
+	if w.visited[key] {
+		return true
+	}
+	w.visited[key] = true
+	return false
+}
+
+func (w *deepWalker) report(path string, got, want any, lines *[]string) {
+	*lines = append(*lines, fmt.Sprintf("%s: got=%s want=%s", path, w.render(got), w.render(want)))
+}
+
+func (w *deepWalker) render(v any) string {
+	s := fmt.Sprintf("%#v", v)
+	if w.maxLen > 0 && len(s) > w.maxLen {
+		s = s[:w.maxLen] + "...(truncated)"
+	}
+	return s
+}
+
+// walk compares a and b at path, appending one line to lines per differing
+// leaf.
+func (w *deepWalker) walk(path string, a, b reflect.Value, lines *[]string) {
+	if !a.IsValid() || !b.IsValid() {
+		if a.IsValid() != b.IsValid() {
+			w.report(path, safeInterface(a), safeInterface(b), lines)
+		}
+		return
+	}
+
+	if a.Type() != b.Type() {
+		w.report(path, safeInterface(a), safeInterface(b), lines)
+		return
+	}
+
+	switch a.Kind() {
+	case reflect.Ptr, reflect.Interface:
+		if a.IsNil() || b.IsNil() {
+			if a.IsNil() != b.IsNil() {
+				w.report(path, safeInterface(a), safeInterface(b), lines)
+			}
+			return
+		}
+		if w.visit(a, b) {
+			return
+		}
+		w.walk(path, a.Elem(), b.Elem(), lines)
+
+	case reflect.Struct:
+		for i := 0; i < a.NumField(); i++ {
+			field := a.Type().Field(i)
+			fa, fb := unexport(a.Field(i)), unexport(b.Field(i))
+			w.walk(joinPath(path, field.Name), fa, fb, lines)
+		}
+
+	case reflect.Slice, reflect.Array:
+		lenA, lenB := a.Len(), b.Len()
+		if isByteSlice(a) {
+			if !reflect.DeepEqual(a.Interface(), b.Interface()) {
+				w.report(path, safeInterface(a), safeInterface(b), lines)
+			}
+			return
+		}
+		if w.visit(a, b) {
+			return
+		}
+		n := lenA
+		if lenB > n {
+			n = lenB
+		}
+		for i := 0; i < n; i++ {
+			idx := fmt.Sprintf("%s[%d]", path, i)
+			switch {
+			case i >= lenA:
+				w.report(idx, nil, safeInterface(b.Index(i)), lines)
+			case i >= lenB:
+				w.report(idx, safeInterface(a.Index(i)), nil, lines)
+			default:
+				w.walk(idx, a.Index(i), b.Index(i), lines)
+			}
+		}
+
+	case reflect.Map:
+		if a.IsNil() != b.IsNil() {
+			w.report(path, safeInterface(a), safeInterface(b), lines)
+			return
+		}
+		if w.visit(a, b) {
+			return
+		}
+		seen := make(map[any]bool, a.Len())
+		for _, key := range a.MapKeys() {
+			seen[key.Interface()] = true
+			keyPath := fmt.Sprintf("%s[%v]", path, key.Interface())
+			bv := b.MapIndex(key)
+			if !bv.IsValid() {
+				w.report(keyPath, safeInterface(a.MapIndex(key)), nil, lines)
+				continue
+			}
+			w.walk(keyPath, a.MapIndex(key), bv, lines)
+		}
+		for _, key := range b.MapKeys() {
+			if seen[key.Interface()] {
+				continue
+			}
+			keyPath := fmt.Sprintf("%s[%v]", path, key.Interface())
+			w.report(keyPath, nil, safeInterface(b.MapIndex(key)), lines)
+		}
+
+	default:
+		if !reflect.DeepEqual(safeInterface(a), safeInterface(b)) {
+			w.report(path, safeInterface(a), safeInterface(b), lines)
+		}
+	}
+}
+
+func joinPath(path, field string) string {
+	if path == "" {
+		return field
+	}
+	return path + "." + field
+}
+
+func isByteSlice(v reflect.Value) bool {
+	return v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Uint8
+}
+
+// unexport makes an unexported but addressable struct field readable via
+// reflection. Unaddressable unexported fields (e.g. of a struct reached
+// through a map value or an interface) are left as-is; safeInterface falls
+// back to a placeholder for those.
+func unexport(v reflect.Value) reflect.Value {
+	if v.CanInterface() || !v.CanAddr() {
+		return v
+	}
+	return reflect.NewAt(v.Type(), unsafe.Pointer(v.UnsafeAddr())).Elem()
+}
+
+func safeInterface(v reflect.Value) any {
+	if !v.IsValid() {
+		return nil
+	}
+	v = unexport(v)
+	if !v.CanInterface() {
+		return fmt.Sprintf("<%s>", v.Type())
+	}
+	return v.Interface()
+}
@@ -0,0 +1,119 @@
+package test
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+type inner struct {
+	Read, Write int
+}
+
+type outer struct {
+	Name     string
+	Timeouts []inner
+	hidden   string
+	next     *outer
+}
+
+func TestDeepReporter_NestedStruct(t *testing.T) {
+	a := outer{Name: "a", Timeouts: []inner{{Read: 5, Write: 1}, {Read: 5, Write: 1}}}
+	b := outer{Name: "a", Timeouts: []inner{{Read: 5, Write: 1}, {Read: 10, Write: 1}}}
+
+	r := NewDeepReporter()
+	out := r.Diff(a, b)
+
+	if !strings.Contains(out, "Timeouts[1].Read: got=5 want=10") {
+		t.Fatalf("expected a path-based diff line, got:\n%s", out)
+	}
+}
+
+func TestDeepReporter_NoDifference(t *testing.T) {
+	a := outer{Name: "a", Timeouts: []inner{{Read: 5, Write: 1}}}
+	b := outer{Name: "a", Timeouts: []inner{{Read: 5, Write: 1}}}
+
+	r := NewDeepReporter()
+	if out := r.Diff(a, b); out != "" {
+		t.Fatalf("expected no diff, got:\n%s", out)
+	}
+}
+
+func TestDeepReporter_UnexportedField(t *testing.T) {
+	a := &outer{hidden: "x"}
+	b := &outer{hidden: "y"}
+
+	r := NewDeepReporter()
+	out := r.Diff(a, b)
+
+	if !strings.Contains(out, "hidden: got=") {
+		t.Fatalf("expected unexported field to be reported, got:\n%s", out)
+	}
+}
+
+// TestDeepReporter_UnexportedFieldByValue covers the common case of a
+// top-level struct passed by value (e.g. test.Eq(t, got, want)), which isn't
+// addressable on its own; Diff must copy it into an addressable value first
+// or unexported-field diffs are silently swallowed.
+func TestDeepReporter_UnexportedFieldByValue(t *testing.T) {
+	a := outer{hidden: "x"}
+	b := outer{hidden: "y"}
+
+	r := NewDeepReporter()
+	out := r.Diff(a, b)
+
+	if !strings.Contains(out, "hidden: got=") {
+		t.Fatalf("expected unexported field to be reported for by-value structs, got:\n%s", out)
+	}
+}
+
+func TestDeepReporter_Cycle(t *testing.T) {
+	a := &outer{Name: "a"}
+	a.next = a
+
+	b := &outer{Name: "b"}
+	b.next = b
+
+	r := NewDeepReporter()
+	out := r.Diff(a, b)
+
+	if !strings.Contains(out, "Name: got=") {
+		t.Fatalf("expected the cyclic structure to still report its leaf diff, got:\n%s", out)
+	}
+}
+
+// TestDeepReporter_MapCycle covers a cycle reached through an interface
+// value rather than a literal pointer (a self-referential map[string]any is
+// the ordinary "JSON-like" shape this comes up in). Diff must terminate
+// instead of recursing forever, so the walk runs on a goroutine with a
+// deadline.
+func TestDeepReporter_MapCycle(t *testing.T) {
+	a := map[string]any{"name": "a"}
+	a["self"] = a
+
+	b := map[string]any{"name": "b"}
+	b["self"] = b
+
+	r := NewDeepReporter()
+
+	done := make(chan string, 1)
+	go func() { done <- r.Diff(a, b) }()
+
+	select {
+	case out := <-done:
+		if !strings.Contains(out, `[name]: got="a" want="b"`) {
+			t.Fatalf("expected the name leaf diff to be reported, got:\n%s", out)
+		}
+	case <-time.After(3 * time.Second):
+		t.Fatal("Diff did not terminate on a self-referential map within 3s")
+	}
+}
+
+func TestDeepReporter_Truncation(t *testing.T) {
+	r := &DeepReporter{MaxLen: 8}
+	out := r.Diff("short", "a very long string that exceeds the max length")
+
+	if !strings.Contains(out, "...(truncated)") {
+		t.Fatalf("expected truncation marker, got:\n%s", out)
+	}
+}